@@ -0,0 +1,223 @@
+// Package codec provides content negotiation for the HTTP API so resource
+// controllers can serve and accept more than just application/json, without
+// each controller re-implementing its own Accept/Content-Type switch.
+package codec
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// ErrEmptyBody is returned by Decode when the request body is empty.
+var ErrEmptyBody = errors.New("Request body can't be empty")
+
+// UnsupportedMediaTypeError is returned when no registered Codec matches the
+// requested media type.
+type UnsupportedMediaTypeError struct {
+	MediaType string
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("unsupported media type: %q", e.MediaType)
+}
+
+// Codec knows how to encode and decode a single wire format.
+type Codec interface {
+	// ContentType is the canonical Content-Type this codec writes.
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string { return "application/json" }
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(b []byte, v interface{}) error { return json.Unmarshal(b, v) }
+
+type yamlCodec struct{}
+
+func (yamlCodec) ContentType() string { return "application/x-yaml" }
+func (yamlCodec) Marshal(v interface{}) ([]byte, error) { return yaml.Marshal(v) }
+func (yamlCodec) Unmarshal(b []byte, v interface{}) error { return yaml.Unmarshal(b, v) }
+
+type xmlCodec struct{}
+
+func (xmlCodec) ContentType() string { return "application/xml" }
+func (xmlCodec) Marshal(v interface{}) ([]byte, error) { return xml.Marshal(v) }
+func (xmlCodec) Unmarshal(b []byte, v interface{}) error { return xml.Unmarshal(b, v) }
+
+// byMediaType maps every media type we accept, on either side of the wire,
+// to the Codec that handles it. Multiple media types can alias the same
+// Codec (e.g. "text/yaml" and "application/x-yaml").
+var byMediaType = map[string]Codec{
+	"application/json":   jsonCodec{},
+	"application/x-yaml": yamlCodec{},
+	"text/yaml":          yamlCodec{},
+	"application/xml":    xmlCodec{},
+	"text/xml":           xmlCodec{},
+}
+
+// defaultCodec is used when a request carries no Accept or Content-Type
+// header at all, preserving the API's historical JSON-only behavior.
+var defaultCodec Codec = jsonCodec{}
+
+// forMediaType resolves a raw header value (which may include parameters,
+// e.g. "application/json; charset=utf-8") to a registered Codec.
+func forMediaType(header string) (Codec, error) {
+	if header == "" {
+		return defaultCodec, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return nil, &UnsupportedMediaTypeError{MediaType: header}
+	}
+
+	if mediaType == "*/*" {
+		return defaultCodec, nil
+	}
+
+	codec, ok := byMediaType[mediaType]
+	if !ok {
+		return nil, &UnsupportedMediaTypeError{MediaType: mediaType}
+	}
+
+	return codec, nil
+}
+
+// acceptRange is one media-range from an Accept header, along with its
+// relative quality value.
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept splits an Accept header into its media-ranges (e.g.
+// "text/html,application/xhtml+xml;q=0.9,*/*;q=0.8"), ordered by
+// descending q-value as RFC 7231 requires. Ranges this package can't parse
+// are skipped rather than failing the whole header, since an Accept header
+// listing formats we don't support alongside ones we do is normal client
+// behavior, not an error.
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+
+	for _, raw := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(raw))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if rawQ, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(rawQ, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		ranges = append(ranges, acceptRange{mediaType: mediaType, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	return ranges
+}
+
+// ForAccept resolves the Codec that should be used to write a response body
+// for the given Accept header. A header listing multiple media-ranges is
+// honored in q-value order; an empty header, a "*/*" range, or a header
+// with no range we recognize all fall back to the default Codec rather
+// than rejecting the request, since most real-world Accept headers (e.g.
+// from browsers) list several formats we don't need to support.
+func ForAccept(accept string) Codec {
+	accept = strings.TrimSpace(accept)
+	if accept == "" {
+		return defaultCodec
+	}
+
+	for _, r := range parseAccept(accept) {
+		if r.mediaType == "*/*" {
+			return defaultCodec
+		}
+		if codec, ok := byMediaType[r.mediaType]; ok {
+			return codec
+		}
+	}
+
+	return defaultCodec
+}
+
+// ForContentType resolves the Codec that should be used to read a request
+// body with the given Content-Type header.
+func ForContentType(contentType string) (Codec, error) {
+	return forMediaType(contentType)
+}
+
+// Decode reads the body of r and unmarshals it into v, selecting a Codec
+// from r's Content-Type header. It reports ErrEmptyBody for an empty body
+// and an *UnsupportedMediaTypeError for a Content-Type with no matching
+// Codec.
+func Decode(r *http.Request, v interface{}) error {
+	codec, err := ForContentType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	if len(body) == 0 {
+		return ErrEmptyBody
+	}
+
+	return codec.Unmarshal(body, v)
+}
+
+// Encode marshals v using a Codec selected from r's Accept header, writes
+// it to w, and sets the Content-Type header to match. An Accept header
+// naming no Codec we support falls back to the default Codec; see
+// ForAccept.
+func Encode(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	codec := ForAccept(r.Header.Get("Accept"))
+
+	bytes, err := codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", codec.ContentType())
+	_, err = w.Write(bytes)
+	return err
+}
+
+// WriteError writes err to w as a response appropriate to its type: 415 for
+// an *UnsupportedMediaTypeError, 400 for ErrEmptyBody, and 500 otherwise.
+// Controllers that handle validation/store errors themselves should only
+// call this for errors returned directly by Decode/Encode.
+func WriteError(w http.ResponseWriter, err error) {
+	switch {
+	case err == ErrEmptyBody:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		if _, ok := err.(*UnsupportedMediaTypeError); ok {
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
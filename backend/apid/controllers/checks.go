@@ -3,15 +3,26 @@ package controllers
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/apid/codec"
 	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/selector"
 	"github.com/sensu/sensu-go/types"
 )
 
+// listResponse is the envelope used for paginated listings, so clients that
+// pass limit/continue get back the cursor alongside the items instead of a
+// bare array.
+type listResponse struct {
+	Items    interface{} `json:"items"`
+	Continue string      `json:"continue"`
+}
+
 // ChecksController defines the fields required by ChecksController.
 type ChecksController struct {
 	Store store.Store
@@ -33,22 +44,115 @@ func (c *ChecksController) many(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	checks, err := c.Store.GetCheckConfigs(r.Context())
+	query := r.URL.Query()
+	paginated := query.Get("limit") != "" || query.Get("continue") != ""
+
+	opts, err := parseListOptions(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	checks, continueToken, err := c.Store.ListCheckConfigs(r.Context(), opts)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Reject those resources the viewer is unauthorized to view
+	// Reject those resources the viewer is unauthorized to view. This runs
+	// after the store query and before the continue token reaches the
+	// client: the token already encodes the store-side cursor, so it
+	// resumes correctly on the next call regardless of what got filtered
+	// out here.
 	rejectChecks(&checks, abilities.CanRead)
 
-	checksBytes, err := json.Marshal(checks)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	var payload interface{} = checks
+	if fields := query.Get("fields"); fields != "" {
+		projected, err := projectFields(checks, strings.Split(fields, ","))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		payload = projected
+	}
+
+	if paginated {
+		if continueToken != "" {
+			w.Header().Set("Sensu-Continue", continueToken)
+		}
+		payload = listResponse{Items: payload, Continue: continueToken}
+	}
+
+	if err := codec.Encode(w, r, payload); err != nil {
+		codec.WriteError(w, err)
+		return
+	}
+}
+
+// parseListOptions translates the query-string pagination and filtering
+// parameters accepted by /checks into a store.ListOptions.
+func parseListOptions(query map[string][]string) (store.ListOptions, error) {
+	get := func(key string) string {
+		if values := query[key]; len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	opts := store.ListOptions{Continue: get("continue")}
+
+	if raw := get("limit"); raw != "" {
+		limit, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return opts, fmt.Errorf("invalid limit: %s", err)
+		}
+		opts.Limit = limit
+	}
+
+	if raw := get("fieldSelector"); raw != "" {
+		sel, err := selector.Parse(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid fieldSelector: %s", err)
+		}
+		opts.FieldSelector = sel
+	}
+
+	if raw := get("labelSelector"); raw != "" {
+		sel, err := selector.Parse(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid labelSelector: %s", err)
+		}
+		opts.LabelSelector = sel
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	fmt.Fprintf(w, string(checksBytes))
+	return opts, nil
+}
+
+// projectFields reduces each check to only the requested top-level JSON
+// fields, for the ?fields= server-side projection parameter.
+func projectFields(checks []*types.CheckConfig, fields []string) ([]map[string]interface{}, error) {
+	projected := make([]map[string]interface{}, 0, len(checks))
+	for _, check := range checks {
+		raw, err := json.Marshal(check)
+		if err != nil {
+			return nil, err
+		}
+
+		var full map[string]interface{}
+		if err := json.Unmarshal(raw, &full); err != nil {
+			return nil, err
+		}
+
+		selected := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			field = strings.TrimSpace(field)
+			if value, ok := full[field]; ok {
+				selected[field] = value
+			}
+		}
+		projected = append(projected, selected)
+	}
+	return projected, nil
 }
 
 // single handles requests to /checks/:name
@@ -83,34 +187,27 @@ func (c *ChecksController) single(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodGet:
-		checkBytes, err := json.Marshal(check)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-
 		if !abilities.CanRead(check) {
 			authorization.UnauthorizedAccessToResource(w)
 			return
 		}
 
-		fmt.Fprintf(w, string(checkBytes))
-	case http.MethodPut, http.MethodPost:
-		newCheck := &types.CheckConfig{}
-		bodyBytes, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+		if check.ResourceVersion != "" {
+			w.Header().Set("ETag", quoteETag(check.ResourceVersion))
 		}
-		defer r.Body.Close()
 
-		err = json.Unmarshal(bodyBytes, newCheck)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		if err := codec.Encode(w, r, check); err != nil {
+			codec.WriteError(w, err)
+			return
+		}
+	case http.MethodPut, http.MethodPost:
+		newCheck := &types.CheckConfig{}
+		if err := codec.Decode(r, newCheck); err != nil {
+			codec.WriteError(w, err)
 			return
 		}
 
-		if err = newCheck.Validate(); err != nil {
+		if err := newCheck.Validate(); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
@@ -123,12 +220,30 @@ func (c *ChecksController) single(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		err = c.Store.UpdateCheckConfig(r.Context(), newCheck)
-		if err != nil {
+		switch ifMatch, ifNoneMatch := r.Header.Get("If-Match"), r.Header.Get("If-None-Match"); {
+		case ifNoneMatch != "" && ifNoneMatch != "*":
+			http.Error(w, `If-None-Match only supports "*"`, http.StatusBadRequest)
+			return
+		case ifNoneMatch == "*":
+			err = c.Store.UpdateCheckConfigCAS(r.Context(), newCheck, "")
+		case ifMatch == "*":
+			err = c.Store.UpdateCheckConfig(r.Context(), newCheck)
+		case ifMatch != "":
+			err = c.Store.UpdateCheckConfigCAS(r.Context(), newCheck, unquoteETag(ifMatch))
+		default:
+			err = c.Store.UpdateCheckConfig(r.Context(), newCheck)
+		}
+
+		switch err {
+		case nil:
+			return
+		case store.ErrPreconditionFailed, store.ErrAlreadyExists:
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			return
+		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		return
 	case http.MethodDelete:
 		err := c.Store.DeleteCheckConfigByName(r.Context(), name)
 		if err != nil {
@@ -139,6 +254,18 @@ func (c *ChecksController) single(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// quoteETag formats a store revision as a strong ETag per RFC 7232.
+func quoteETag(revision string) string {
+	return `"` + revision + `"`
+}
+
+// unquoteETag strips the weak-validator prefix and surrounding quotes from
+// an If-Match/ETag header value, leaving the bare revision.
+func unquoteETag(etag string) string {
+	etag = strings.TrimPrefix(etag, "W/")
+	return strings.Trim(etag, `"`)
+}
+
 func rejectChecks(records *[]*types.CheckConfig, predicate func(*types.CheckConfig) bool) {
 	for i := 0; i < len(*records); i++ {
 		if !predicate((*records)[i]) {
@@ -0,0 +1,12 @@
+package store
+
+import "errors"
+
+// ErrPreconditionFailed is returned by a CAS update method when the
+// caller's expected revision (surfaced over HTTP as If-Match) does not
+// match the resource's current revision in the store.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// ErrAlreadyExists is returned by a CAS create (surfaced over HTTP as
+// If-None-Match: *) when a resource already exists under the same name.
+var ErrAlreadyExists = errors.New("resource already exists")
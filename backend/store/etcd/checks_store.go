@@ -0,0 +1,129 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/types"
+)
+
+// checkConfigsPathPrefix is the etcd key prefix all check configurations
+// are stored under.
+const checkConfigsPathPrefix = "/sensu.io/checks/"
+
+func checkConfigKey(name string) string {
+	return checkConfigsPathPrefix + name
+}
+
+// GetCheckConfigs returns all check configurations.
+func (s *Store) GetCheckConfigs(ctx context.Context) ([]*types.CheckConfig, error) {
+	resp, err := s.client.Get(ctx, checkConfigsPathPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]*types.CheckConfig, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		check, err := decodeCheckConfig(kv)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// GetCheckConfigByName returns a check configuration by name, or a nil
+// check when none exists.
+func (s *Store) GetCheckConfigByName(ctx context.Context, name string) (*types.CheckConfig, error) {
+	resp, err := s.client.Get(ctx, checkConfigKey(name))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+
+	return decodeCheckConfig(resp.Kvs[0])
+}
+
+// UpdateCheckConfig creates or updates a check configuration unconditionally.
+func (s *Store) UpdateCheckConfig(ctx context.Context, check *types.CheckConfig) error {
+	bytes, err := json.Marshal(check)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Put(ctx, checkConfigKey(check.Name), string(bytes))
+	return err
+}
+
+// UpdateCheckConfigCAS creates or updates a check configuration only if its
+// current revision in etcd matches expectedRevision, using a transaction
+// comparing against the key's mod_revision so the check holds under
+// concurrent writers. An empty expectedRevision means "create only if
+// absent": the transaction compares against create_revision instead, and
+// ErrAlreadyExists is returned if the key is already present. Any other
+// comparison failure returns ErrPreconditionFailed.
+func (s *Store) UpdateCheckConfigCAS(ctx context.Context, check *types.CheckConfig, expectedRevision string) error {
+	bytes, err := json.Marshal(check)
+	if err != nil {
+		return err
+	}
+
+	key := checkConfigKey(check.Name)
+	put := clientv3.OpPut(key, string(bytes))
+
+	if expectedRevision == "" {
+		resp, err := s.client.Txn(ctx).
+			If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+			Then(put).
+			Commit()
+		if err != nil {
+			return err
+		}
+		if !resp.Succeeded {
+			return store.ErrAlreadyExists
+		}
+		return nil
+	}
+
+	expected, err := strconv.ParseInt(expectedRevision, 10, 64)
+	if err != nil {
+		return store.ErrPreconditionFailed
+	}
+
+	resp, err := s.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(key), "=", expected)).
+		Then(put).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return store.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// DeleteCheckConfigByName deletes a check configuration by name.
+func (s *Store) DeleteCheckConfigByName(ctx context.Context, name string) error {
+	_, err := s.client.Delete(ctx, checkConfigKey(name))
+	return err
+}
+
+// decodeCheckConfig unmarshals a check configuration from an etcd key-value
+// pair, stamping ResourceVersion from the key's mod_revision.
+func decodeCheckConfig(kv *mvccpb.KeyValue) (*types.CheckConfig, error) {
+	check := &types.CheckConfig{}
+	if err := json.Unmarshal(kv.Value, check); err != nil {
+		return nil, err
+	}
+	check.ResourceVersion = strconv.FormatInt(kv.ModRevision, 10)
+	return check, nil
+}
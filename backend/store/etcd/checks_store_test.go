@@ -0,0 +1,82 @@
+package etcd
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/coreos/etcd/integration"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/types"
+)
+
+func TestUpdateCheckConfigCASConcurrentWriters(t *testing.T) {
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	s := NewStore(cluster.RandClient())
+	ctx := context.Background()
+
+	check := &types.CheckConfig{Name: "concurrency-check", Command: "true"}
+	if err := s.UpdateCheckConfig(ctx, check); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	stored, err := s.GetCheckConfigByName(ctx, check.Name)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if stored == nil {
+		t.Fatal("expected check to exist")
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			update := &types.CheckConfig{
+				Name:     check.Name,
+				Command:  "true",
+				Interval: uint32(i + 1),
+			}
+			errs[i] = s.UpdateCheckConfigCAS(ctx, update, stored.ResourceVersion)
+		}(i)
+	}
+	wg.Wait()
+
+	var succeeded, failed int
+	for _, err := range errs {
+		switch err {
+		case nil:
+			succeeded++
+		case store.ErrPreconditionFailed:
+			failed++
+		default:
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if succeeded != 1 || failed != 1 {
+		t.Fatalf("expected exactly one writer to succeed and one to get ErrPreconditionFailed, got %d succeeded, %d failed", succeeded, failed)
+	}
+}
+
+func TestUpdateCheckConfigCASCreateOnlyIfAbsent(t *testing.T) {
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	s := NewStore(cluster.RandClient())
+	ctx := context.Background()
+
+	check := &types.CheckConfig{Name: "create-once-check", Command: "true"}
+
+	if err := s.UpdateCheckConfigCAS(ctx, check, ""); err != nil {
+		t.Fatalf("expected first create to succeed, got: %s", err)
+	}
+
+	if err := s.UpdateCheckConfigCAS(ctx, check, ""); err != store.ErrAlreadyExists {
+		t.Fatalf("expected ErrAlreadyExists, got: %v", err)
+	}
+}
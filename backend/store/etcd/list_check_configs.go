@@ -0,0 +1,119 @@
+package etcd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/selector"
+	"github.com/sensu/sensu-go/types"
+)
+
+// listFetchBatch bounds how many keys ListCheckConfigs pulls from etcd per
+// round trip while hunting for opts.Limit selector-matching items. etcd has
+// no server-side way to filter by value, so a selector that rejects most
+// checks can require several round trips to fill a page.
+const listFetchBatch = 256
+
+// ListCheckConfigs returns a page of check configurations matching opts,
+// along with an opaque continue token to resume listing, or an empty token
+// once the listing is exhausted.
+func (s *Store) ListCheckConfigs(ctx context.Context, opts store.ListOptions) ([]*types.CheckConfig, string, error) {
+	rangeStart := checkConfigsPathPrefix
+	if opts.Continue != "" {
+		key, err := decodeContinueToken(opts.Continue)
+		if err != nil {
+			return nil, "", err
+		}
+		rangeStart = key
+	}
+	rangeEnd := clientv3.GetPrefixRangeEnd(checkConfigsPathPrefix)
+
+	matched := make([]*types.CheckConfig, 0)
+
+	for {
+		getOpts := []clientv3.OpOption{clientv3.WithRange(rangeEnd)}
+		if opts.Limit > 0 {
+			getOpts = append(getOpts, clientv3.WithLimit(listFetchBatch))
+		}
+
+		resp, err := s.client.Get(ctx, rangeStart, getOpts...)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(resp.Kvs) == 0 {
+			return matched, "", nil
+		}
+
+		for i, kv := range resp.Kvs {
+			check, err := decodeCheckConfig(kv)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if !matchesListSelectors(check, opts) {
+				continue
+			}
+
+			matched = append(matched, check)
+
+			if opts.Limit > 0 && int64(len(matched)) == opts.Limit {
+				return matched, continueTokenAfter(resp.Kvs, i), nil
+			}
+		}
+
+		if !resp.More {
+			return matched, "", nil
+		}
+
+		// Resume just past the last key examined. Appending a NUL byte
+		// gives the lexicographically-next possible key, so the range
+		// start is effectively exclusive without etcd needing to support
+		// that directly.
+		rangeStart = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+}
+
+// continueTokenAfter builds the opaque continue token that resumes listing
+// right after kvs[i], whether or not there's a next key already in hand.
+func continueTokenAfter(kvs []*mvccpb.KeyValue, i int) string {
+	if i+1 < len(kvs) {
+		return encodeContinueToken(string(kvs[i+1].Key))
+	}
+	return encodeContinueToken(string(kvs[i].Key) + "\x00")
+}
+
+// matchesListSelectors reports whether check satisfies opts' field and
+// label selectors. A check with no selectors set always matches.
+// fieldSelector matches against the check's own JSON-tagged fields (e.g.
+// name, subscriptions); labelSelector matches against check.Labels instead,
+// since labels are user metadata rather than part of the check spec.
+func matchesListSelectors(check *types.CheckConfig, opts store.ListOptions) bool {
+	if len(opts.FieldSelector) > 0 {
+		get, err := selector.FromJSON(check)
+		if err != nil || !opts.FieldSelector.Matches(get) {
+			return false
+		}
+	}
+
+	if len(opts.LabelSelector) > 0 && !opts.LabelSelector.Matches(selector.FromLabels(check.Labels)) {
+		return false
+	}
+
+	return true
+}
+
+func encodeContinueToken(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func decodeContinueToken(token string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("invalid continue token: %s", err)
+	}
+	return string(raw), nil
+}
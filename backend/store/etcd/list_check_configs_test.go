@@ -0,0 +1,162 @@
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coreos/etcd/integration"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/selector"
+	"github.com/sensu/sensu-go/types"
+)
+
+func TestListCheckConfigsPagination(t *testing.T) {
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	s := NewStore(cluster.RandClient())
+	ctx := context.Background()
+
+	names := []string{"check-a", "check-b", "check-c"}
+	for _, name := range names {
+		check := &types.CheckConfig{Name: name, Command: "true"}
+		if err := s.UpdateCheckConfig(ctx, check); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	var seen []string
+	opts := store.ListOptions{Limit: 1}
+	for {
+		page, continueToken, err := s.ListCheckConfigs(ctx, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, check := range page {
+			seen = append(seen, check.Name)
+		}
+		if continueToken == "" {
+			break
+		}
+		opts.Continue = continueToken
+	}
+
+	if len(seen) != len(names) {
+		t.Fatalf("expected %d checks across pages, got %d: %v", len(names), len(seen), seen)
+	}
+	for i, name := range names {
+		if seen[i] != name {
+			t.Fatalf("expected page order %v, got %v", names, seen)
+		}
+	}
+}
+
+func TestListCheckConfigsFieldSelector(t *testing.T) {
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	s := NewStore(cluster.RandClient())
+	ctx := context.Background()
+
+	checks := []*types.CheckConfig{
+		{Name: "linux-check", Command: "true", Subscriptions: []string{"linux"}},
+		{Name: "windows-check", Command: "true", Subscriptions: []string{"windows"}},
+		{Name: "mixed-check", Command: "true", Subscriptions: []string{"linux", "windows"}},
+	}
+	for _, check := range checks {
+		if err := s.UpdateCheckConfig(ctx, check); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	sel, err := selector.Parse("name=linux-check")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	page, _, err := s.ListCheckConfigs(ctx, store.ListOptions{FieldSelector: sel})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(page) != 1 || page[0].Name != "linux-check" {
+		t.Fatalf("expected only linux-check to match, got %v", page)
+	}
+}
+
+func TestListCheckConfigsFieldSelectorMultiValue(t *testing.T) {
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	s := NewStore(cluster.RandClient())
+	ctx := context.Background()
+
+	checks := []*types.CheckConfig{
+		{Name: "linux-check", Command: "true", Subscriptions: []string{"linux"}},
+		{Name: "windows-check", Command: "true", Subscriptions: []string{"windows"}},
+		{Name: "mixed-check", Command: "true", Subscriptions: []string{"linux", "windows"}},
+	}
+	for _, check := range checks {
+		if err := s.UpdateCheckConfig(ctx, check); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// subscriptions=linux should match every check that has "linux" among
+	// its subscriptions, not only a check whose sole subscription is
+	// "linux".
+	sel, err := selector.Parse("subscriptions=linux")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	page, _, err := s.ListCheckConfigs(ctx, store.ListOptions{FieldSelector: sel})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	seen := make(map[string]bool, len(page))
+	for _, check := range page {
+		seen[check.Name] = true
+	}
+	if len(page) != 2 || !seen["linux-check"] || !seen["mixed-check"] {
+		t.Fatalf("expected linux-check and mixed-check to match, got %v", page)
+	}
+}
+
+func TestListCheckConfigsLabelSelector(t *testing.T) {
+	cluster := integration.NewClusterV3(t, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	s := NewStore(cluster.RandClient())
+	ctx := context.Background()
+
+	checks := []*types.CheckConfig{
+		{Name: "prod-check", Command: "true", Labels: map[string]string{"env": "prod"}},
+		{Name: "stage-check", Command: "true", Labels: map[string]string{"env": "stage"}},
+		{Name: "dev-check", Command: "true", Labels: map[string]string{"env": "dev"}},
+	}
+	for _, check := range checks {
+		if err := s.UpdateCheckConfig(ctx, check); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	sel, err := selector.Parse("env in (prod,stage)")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	page, _, err := s.ListCheckConfigs(ctx, store.ListOptions{LabelSelector: sel})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	seen := make(map[string]bool, len(page))
+	for _, check := range page {
+		seen[check.Name] = true
+	}
+	if len(page) != 2 || !seen["prod-check"] || !seen["stage-check"] {
+		t.Fatalf("expected prod-check and stage-check to match, got %v", page)
+	}
+}
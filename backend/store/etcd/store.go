@@ -0,0 +1,16 @@
+// Package etcd implements backend/store.Store on top of etcd.
+package etcd
+
+import (
+	"github.com/coreos/etcd/clientv3"
+)
+
+// Store is an etcd-backed implementation of store.Store.
+type Store struct {
+	client *clientv3.Client
+}
+
+// NewStore creates a Store backed by client.
+func NewStore(client *clientv3.Client) *Store {
+	return &Store{client: client}
+}
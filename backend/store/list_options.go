@@ -0,0 +1,20 @@
+package store
+
+import "github.com/sensu/sensu-go/backend/store/selector"
+
+// ListOptions controls pagination and filtering for a store's List* methods.
+type ListOptions struct {
+	// Limit caps the number of items a List call returns. Zero means no
+	// limit.
+	Limit int64
+
+	// Continue is an opaque cursor returned by a previous List call,
+	// encoding enough state to resume listing where it left off.
+	Continue string
+
+	// FieldSelector and LabelSelector narrow the result set to items whose
+	// fields, respectively labels, satisfy the selector. A nil Selector
+	// matches everything.
+	FieldSelector selector.Selector
+	LabelSelector selector.Selector
+}
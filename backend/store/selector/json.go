@@ -0,0 +1,60 @@
+package selector
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FromJSON builds a FieldGetter backed by v's JSON representation, keyed by
+// its json struct tags. This lets every resource type satisfy fieldSelector
+// without bespoke glue code per controller. A field whose JSON value is an
+// array (e.g. subscriptions) is exposed as one value per element, so
+// requirements match against any member rather than the field as a whole.
+func FromJSON(v interface{}) (FieldGetter, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+
+	return func(key string) ([]string, bool) {
+		value, ok := fields[key]
+		if !ok {
+			return nil, false
+		}
+		return toStrings(value), true
+	}, nil
+}
+
+// FromLabels builds a FieldGetter backed by a resource's labels map, for
+// labelSelector matching. Unlike FromJSON, every key is single-valued.
+func FromLabels(labels map[string]string) FieldGetter {
+	return func(key string) ([]string, bool) {
+		value, ok := labels[key]
+		if !ok {
+			return nil, false
+		}
+		return []string{value}, true
+	}
+}
+
+func toStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, toStrings(item)...)
+		}
+		return values
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprintf("%v", v)}
+	}
+}
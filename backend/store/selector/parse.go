@@ -0,0 +1,135 @@
+package selector
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parse parses a comma-separated list of requirements into a Selector. Each
+// requirement is one of:
+//
+//	key=value
+//	key!=value
+//	key in (value1,value2,...)
+//	key notin (value1,value2,...)
+//
+// An empty (or all-whitespace) raw string yields a nil Selector that
+// matches everything.
+func Parse(raw string) (Selector, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	clauses, err := splitRequirements(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := make(Selector, 0, len(clauses))
+	for _, clause := range clauses {
+		requirement, err := parseRequirement(clause)
+		if err != nil {
+			return nil, err
+		}
+		selector = append(selector, requirement)
+	}
+
+	return selector, nil
+}
+
+// splitRequirements splits raw on commas that are not nested inside the
+// parentheses of an "in"/"notin" value list.
+func splitRequirements(raw string) ([]string, error) {
+	var clauses []string
+
+	depth := 0
+	start := 0
+	for i, r := range raw {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("selector: unbalanced parentheses in %q", raw)
+			}
+		case ',':
+			if depth == 0 {
+				clauses = append(clauses, raw[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("selector: unbalanced parentheses in %q", raw)
+	}
+
+	return append(clauses, raw[start:]), nil
+}
+
+func parseRequirement(clause string) (Requirement, error) {
+	clause = strings.TrimSpace(clause)
+	if clause == "" {
+		return Requirement{}, fmt.Errorf("selector: empty requirement")
+	}
+
+	if idx := strings.Index(clause, "!="); idx >= 0 {
+		return newRequirement(clause[:idx], OperatorNotEquals, clause[idx+2:])
+	}
+	if idx := strings.Index(clause, "="); idx >= 0 {
+		return newRequirement(clause[:idx], OperatorEquals, clause[idx+1:])
+	}
+
+	key, rest, ok := cutSpace(clause)
+	if !ok {
+		return Requirement{}, fmt.Errorf("selector: could not parse requirement %q", clause)
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "notin"):
+		return newRequirement(key, OperatorNotIn, strings.TrimPrefix(rest, "notin"))
+	case strings.HasPrefix(rest, "in"):
+		return newRequirement(key, OperatorIn, strings.TrimPrefix(rest, "in"))
+	default:
+		return Requirement{}, fmt.Errorf("selector: could not parse requirement %q", clause)
+	}
+}
+
+// cutSpace splits clause at its first space character and trims whitespace
+// around the remainder.
+func cutSpace(clause string) (key, rest string, ok bool) {
+	fields := strings.SplitN(clause, " ", 2)
+	if len(fields) != 2 {
+		return "", "", false
+	}
+	return fields[0], strings.TrimSpace(fields[1]), true
+}
+
+func newRequirement(key string, op Operator, rawValues string) (Requirement, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return Requirement{}, fmt.Errorf("selector: requirement is missing a key")
+	}
+
+	rawValues = strings.TrimSpace(rawValues)
+	rawValues = strings.TrimPrefix(rawValues, "(")
+	rawValues = strings.TrimSuffix(rawValues, ")")
+
+	var values []string
+	for _, v := range strings.Split(rawValues, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return Requirement{}, fmt.Errorf("selector: requirement %q has no values", key)
+	}
+	if (op == OperatorEquals || op == OperatorNotEquals) && len(values) != 1 {
+		return Requirement{}, fmt.Errorf("selector: %q operator takes exactly one value", op)
+	}
+
+	return Requirement{Key: key, Operator: op, Values: values}, nil
+}
@@ -0,0 +1,104 @@
+// Package selector implements a small Kubernetes-style query language for
+// filtering resources by field or label, e.g. "name=foo,subscriptions=linux"
+// or "env in (prod,stage)". It is shared by the store layer, which applies
+// selectors to the items it reads back from its underlying storage, and
+// callers that merely need to validate a selector string before it reaches
+// the store.
+package selector
+
+import "strings"
+
+// Operator is a selector requirement's comparison operator.
+type Operator string
+
+// Supported operators.
+const (
+	OperatorEquals    Operator = "="
+	OperatorNotEquals Operator = "!="
+	OperatorIn        Operator = "in"
+	OperatorNotIn     Operator = "notin"
+)
+
+// Requirement is a single "key OP values" clause, e.g. "name=foo" or
+// "env in (prod,stage)".
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Matches reports whether values, the subject's value(s) for the
+// requirement's Key, satisfies the requirement. A multi-valued field (e.g.
+// subscriptions) is treated as a set: "="/"!=" test whether any member
+// equals the requirement's single value, and "in"/"notin" test whether any
+// member is one of the requirement's values.
+func (r Requirement) Matches(values []string) bool {
+	switch r.Operator {
+	case OperatorEquals:
+		return len(r.Values) == 1 && contains(values, r.Values[0])
+	case OperatorNotEquals:
+		return len(r.Values) == 1 && !contains(values, r.Values[0])
+	case OperatorIn:
+		return containsAny(values, r.Values)
+	case OperatorNotIn:
+		return !containsAny(values, r.Values)
+	default:
+		return false
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, candidate := range candidates {
+		if contains(values, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// Selector is an ordered set of Requirements, all of which must match
+// (AND semantics). A nil or empty Selector matches everything.
+type Selector []Requirement
+
+// FieldGetter resolves the value(s) of a named field on some resource. A
+// single-valued field returns a single-element slice; a multi-valued field
+// (e.g. subscriptions) returns one element per member, so "in"/"notin"
+// requirements can match any member rather than the field as a whole.
+type FieldGetter func(field string) (values []string, ok bool)
+
+// Matches reports whether every requirement in s is satisfied by get.
+func (s Selector) Matches(get FieldGetter) bool {
+	for _, r := range s {
+		values, ok := get(r.Key)
+		if !ok {
+			return false
+		}
+		if !r.Matches(values) {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders s back into the query syntax accepted by Parse.
+func (s Selector) String() string {
+	clauses := make([]string, 0, len(s))
+	for _, r := range s {
+		switch r.Operator {
+		case OperatorEquals, OperatorNotEquals:
+			clauses = append(clauses, r.Key+string(r.Operator)+r.Values[0])
+		default:
+			clauses = append(clauses, r.Key+" "+string(r.Operator)+" ("+strings.Join(r.Values, ",")+")")
+		}
+	}
+	return strings.Join(clauses, ",")
+}
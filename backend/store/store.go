@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+
+	"github.com/sensu/sensu-go/types"
+)
+
+// Store is used to abstract the durable storage layer used by the backend.
+type Store interface {
+	// GetCheckConfigs returns all check configurations.
+	GetCheckConfigs(ctx context.Context) ([]*types.CheckConfig, error)
+
+	// ListCheckConfigs returns a page of check configurations matching
+	// opts, along with an opaque token to pass as opts.Continue on the
+	// next call to resume listing, or an empty token once the listing is
+	// exhausted.
+	ListCheckConfigs(ctx context.Context, opts ListOptions) (checks []*types.CheckConfig, continueToken string, err error)
+
+	// GetCheckConfigByName returns a check configuration by name. It
+	// returns a nil check, rather than an error, when none exists.
+	GetCheckConfigByName(ctx context.Context, name string) (*types.CheckConfig, error)
+
+	// UpdateCheckConfig creates or updates a check configuration.
+	UpdateCheckConfig(ctx context.Context, check *types.CheckConfig) error
+
+	// UpdateCheckConfigCAS creates or updates a check configuration only if
+	// its current revision in the store equals expectedRevision, performing
+	// the comparison atomically against the store's own revisioning (e.g.
+	// etcd's mod_revision) so the guarantee holds under concurrent writers.
+	// An empty expectedRevision means "create only if absent": it returns
+	// ErrAlreadyExists if a check with the same name is already present.
+	// Any other mismatch returns ErrPreconditionFailed.
+	UpdateCheckConfigCAS(ctx context.Context, check *types.CheckConfig, expectedRevision string) error
+
+	// DeleteCheckConfigByName deletes a check configuration by name.
+	DeleteCheckConfigByName(ctx context.Context, name string) error
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/types"
+	"github.com/spf13/cobra"
+)
+
+// CreateCommand allows the user to create new handlers
+func CreateCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "create NAME",
+		Short:        "create new handlers",
+		SilenceUsage: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				cmd.Help()
+				return nil
+			}
+
+			handler := &types.Handler{
+				Name:         args[0],
+				Environment:  cli.Config.Environment(),
+				Organization: cli.Config.Organization(),
+			}
+
+			opts := newHandlerOpts()
+			opts.withHandler(handler)
+
+			nonInteractive := specFlagsChanged(cmd.Flags())
+			switch {
+			case nonInteractive:
+				if err := applyFlags(cmd, opts); err != nil {
+					fmt.Fprintln(cmd.OutOrStderr(), err)
+					os.Exit(exitCodeInvalid)
+				}
+			case isatty.IsTerminal(os.Stdin.Fd()):
+				if err := opts.administerQuestionnaire(false); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("create requires either a TTY or one of the non-interactive flags (see --help)")
+			}
+
+			opts.Copy(handler)
+
+			if err := handler.Validate(); err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err)
+				os.Exit(exitCodeInvalid)
+			}
+
+			if err := cli.Client.CreateHandler(handler); err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err)
+				os.Exit(exitCodeAPIError)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "OK")
+			return nil
+		},
+	}
+
+	addSpecFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Exit codes returned by the handler commands so scripts and config
+// management tooling can distinguish failure modes without scraping stderr.
+const (
+	exitCodeOK       = 0
+	exitCodeNotFound = 2
+	exitCodeInvalid  = 3
+	exitCodeAPIError = 4
+)
+
+// specFlagNames lists the flags owned by addSpecFlags. specFlagsChanged only
+// looks at these, so inherited/global flags (--format, --namespace, etc.)
+// don't falsely signal non-interactive mode.
+var specFlagNames = []string{
+	"type", "command", "timeout", "filters", "mutator", "socket-host",
+	"socket-port", "handlers", "runtime-assets", "env-vars", "from-file",
+	"stdin",
+}
+
+// statusCoder is implemented by client errors that carry the HTTP status
+// they came from, letting callers distinguish "not found" from other API
+// errors without depending on the client package's concrete error type.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// exitCodeForFetchError picks the exit code for a FetchHandler error: a 404
+// from the API is exitCodeNotFound, anything else is exitCodeAPIError.
+func exitCodeForFetchError(err error) int {
+	if sc, ok := err.(statusCoder); ok && sc.StatusCode() == http.StatusNotFound {
+		return exitCodeNotFound
+	}
+	return exitCodeAPIError
+}
+
+// addSpecFlags attaches the flags that drive the non-interactive handler
+// create/update path. Their presence (cmd.Flags().Changed) is how callers
+// decide whether to skip the questionnaire.
+func addSpecFlags(cmd *cobra.Command) {
+	cmd.Flags().String("type", "", "handler type (pipe, tcp, udp, set)")
+	cmd.Flags().StringSlice("command", nil, "command to be executed by the handler")
+	cmd.Flags().Int("timeout", 0, "handler timeout, in seconds")
+	cmd.Flags().StringSlice("filters", nil, "filters to apply before executing the handler")
+	cmd.Flags().String("mutator", "", "mutator to apply to event data before it reaches the handler")
+	cmd.Flags().String("socket-host", "", "socket host, for tcp and udp handlers")
+	cmd.Flags().String("socket-port", "", "socket port, for tcp and udp handlers")
+	cmd.Flags().StringSlice("handlers", nil, "handlers to call, for set handlers")
+	cmd.Flags().StringSlice("runtime-assets", nil, "runtime assets required by the handler")
+	cmd.Flags().StringToString("env-vars", nil, "environment variables available to the handler, key=value")
+	cmd.Flags().String("from-file", "", "path to a yaml or json file containing the handler spec")
+	cmd.Flags().Bool("stdin", false, "read the handler spec from stdin")
+}
+
+// specFlagsChanged reports whether any of the flags addSpecFlags attaches
+// were set, which is the signal to skip administerQuestionnaire. It only
+// checks those flags, not flags.Visit's full set, so inherited flags like
+// --format or --namespace don't trigger non-interactive mode.
+func specFlagsChanged(flags *pflag.FlagSet) bool {
+	for _, name := range specFlagNames {
+		if flags.Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyFlags merges whichever of the non-interactive flags were set onto
+// opts; callers then copy opts onto the handler with opts.Copy. --from-file
+// and --stdin take the whole spec and are applied first so that individual
+// flags can still override specific fields.
+func applyFlags(cmd *cobra.Command, opts *handlerOpts) error {
+	flags := cmd.Flags()
+
+	fromFile, _ := flags.GetString("from-file")
+	stdin, _ := flags.GetBool("stdin")
+
+	switch {
+	case fromFile != "" && stdin:
+		return fmt.Errorf("--from-file and --stdin are mutually exclusive")
+	case fromFile != "":
+		bytes, err := ioutil.ReadFile(fromFile)
+		if err != nil {
+			return fmt.Errorf("could not read %s: %s", fromFile, err)
+		}
+		if err := unmarshalHandlerSpec(bytes, filepath.Ext(fromFile), opts); err != nil {
+			return err
+		}
+	case stdin:
+		bytes, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("could not read stdin: %s", err)
+		}
+		if err := unmarshalHandlerSpec(bytes, ".yaml", opts); err != nil {
+			return err
+		}
+	}
+
+	if v, _ := flags.GetString("type"); flags.Changed("type") {
+		opts.Type = v
+	}
+	if v, _ := flags.GetStringSlice("command"); flags.Changed("command") {
+		opts.Command = strings.Join(v, " ")
+	}
+	if v, _ := flags.GetInt("timeout"); flags.Changed("timeout") {
+		opts.Timeout = strconv.Itoa(v)
+	}
+	if v, _ := flags.GetStringSlice("filters"); flags.Changed("filters") {
+		opts.Filters = v
+	}
+	if v, _ := flags.GetString("mutator"); flags.Changed("mutator") {
+		opts.Mutator = v
+	}
+	if v, _ := flags.GetString("socket-host"); flags.Changed("socket-host") {
+		opts.SocketHost = v
+	}
+	if v, _ := flags.GetString("socket-port"); flags.Changed("socket-port") {
+		opts.SocketPort = v
+	}
+	if v, _ := flags.GetStringSlice("handlers"); flags.Changed("handlers") {
+		opts.Handlers = v
+	}
+	if v, _ := flags.GetStringSlice("runtime-assets"); flags.Changed("runtime-assets") {
+		opts.RuntimeAssets = v
+	}
+	if v, _ := flags.GetStringToString("env-vars"); flags.Changed("env-vars") {
+		opts.EnvVars = envVarsToSlice(v)
+	}
+
+	return nil
+}
+
+// unmarshalHandlerSpec decodes a full handler spec from bytes (json if ext
+// is ".json", yaml otherwise) and merges its non-zero fields onto opts, so
+// the remaining flag handling and administerQuestionnaire machinery can
+// stay in terms of handlerOpts. Fields the spec leaves unset - e.g. Name,
+// Environment, and Organization when --from-file only specifies behavior
+// fields - keep whatever withHandler already populated from the fetched
+// handler, instead of being blanked out.
+func unmarshalHandlerSpec(bytes []byte, ext string, opts *handlerOpts) error {
+	spec := &handlerOpts{}
+
+	var err error
+	if strings.EqualFold(ext, ".json") {
+		err = json.Unmarshal(bytes, spec)
+	} else {
+		err = yaml.Unmarshal(bytes, spec)
+	}
+	if err != nil {
+		return fmt.Errorf("could not parse handler spec: %s", err)
+	}
+
+	mergeNonZero(opts, spec)
+	return nil
+}
+
+// mergeNonZero copies every field from src onto dst that is non-zero
+// valued, leaving fields src leaves unset untouched on dst.
+func mergeNonZero(dst, src *handlerOpts) {
+	dstVal := reflect.ValueOf(dst).Elem()
+	srcVal := reflect.ValueOf(src).Elem()
+
+	for i := 0; i < srcVal.NumField(); i++ {
+		field := srcVal.Field(i)
+		zero := reflect.Zero(field.Type()).Interface()
+		if !reflect.DeepEqual(field.Interface(), zero) {
+			dstVal.Field(i).Set(field)
+		}
+	}
+}
+
+func envVarsToSlice(m map[string]string) []string {
+	vars := make([]string, 0, len(m))
+	for k, v := range m {
+		vars = append(vars, fmt.Sprintf("%s=%s", k, v))
+	}
+	return vars
+}
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/mattn/go-isatty"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/spf13/cobra"
 )
@@ -24,24 +26,38 @@ func UpdateCommand(cli *cli.SensuCli) *cobra.Command {
 			handlerName := args[0]
 			handler, err := cli.Client.FetchHandler(handlerName)
 			if err != nil {
-				return err
+				fmt.Fprintln(cmd.OutOrStderr(), err)
+				os.Exit(exitCodeForFetchError(err))
 			}
 
 			opts := newHandlerOpts()
 			opts.withHandler(handler)
 
-			if err := opts.administerQuestionnaire(true); err != nil {
-				return err
+			nonInteractive := specFlagsChanged(cmd.Flags())
+			switch {
+			case nonInteractive:
+				if err := applyFlags(cmd, opts); err != nil {
+					fmt.Fprintln(cmd.OutOrStderr(), err)
+					os.Exit(exitCodeInvalid)
+				}
+			case isatty.IsTerminal(os.Stdin.Fd()):
+				if err := opts.administerQuestionnaire(true); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("update requires either a TTY or one of the non-interactive flags (see --help)")
 			}
 
 			opts.Copy(handler)
 
 			if err := handler.Validate(); err != nil {
-				return err
+				fmt.Fprintln(cmd.OutOrStderr(), err)
+				os.Exit(exitCodeInvalid)
 			}
 
 			if err := cli.Client.CreateHandler(handler); err != nil {
-				return err
+				fmt.Fprintln(cmd.OutOrStderr(), err)
+				os.Exit(exitCodeAPIError)
 			}
 
 			fmt.Fprintln(cmd.OutOrStdout(), "OK")
@@ -49,5 +65,7 @@ func UpdateCommand(cli *cli.SensuCli) *cobra.Command {
 		},
 	}
 
+	addSpecFlags(cmd)
+
 	return cmd
-}
\ No newline at end of file
+}
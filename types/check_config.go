@@ -0,0 +1,37 @@
+package types
+
+import "errors"
+
+// CheckConfig is the specification of a check configuration.
+type CheckConfig struct {
+	Name          string   `json:"name"`
+	Command       string   `json:"command"`
+	Subscriptions []string `json:"subscriptions,omitempty"`
+	Handlers      []string `json:"handlers,omitempty"`
+	Interval      uint32   `json:"interval,omitempty"`
+	Organization  string   `json:"organization,omitempty"`
+	Environment   string   `json:"environment,omitempty"`
+
+	// Labels are arbitrary user-supplied key/value metadata, matched by
+	// labelSelector independently of Name/Command/etc, which are matched by
+	// fieldSelector.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ResourceVersion identifies the revision of this check as last
+	// observed in the store (e.g. etcd's mod_revision). It is populated by
+	// the store on read, not supplied by clients, and is how
+	// ChecksController implements optimistic concurrency over HTTP via
+	// ETag/If-Match.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// Validate returns an error if the check is missing a name or command.
+func (c *CheckConfig) Validate() error {
+	if c.Name == "" {
+		return errors.New("name cannot be blank")
+	}
+	if c.Command == "" {
+		return errors.New("command cannot be blank")
+	}
+	return nil
+}